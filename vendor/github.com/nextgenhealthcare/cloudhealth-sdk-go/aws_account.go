@@ -1,15 +1,13 @@
 package cloudhealth
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 // AwsAccount represents the configuration of an AWS Account enabled in CloudHealth.
@@ -17,11 +15,58 @@ type AwsAccount struct {
 	ID             int                      `json:"id"`
 	Name           string                   `json:"name"`
 	Authentication AwsAccountAuthentication `json:"authentication"`
+	Tags           []AccountTag             `json:"tags,omitempty"`
+	Billing        *AwsAccountBilling       `json:"billing,omitempty"`
+	CloudTrail     *AwsAccountCloudTrail    `json:"cloudtrail,omitempty"`
+	AwsConfig      *AwsAccountConfig        `json:"aws_config,omitempty"`
+	DBR            *AwsAccountDBR           `json:"dbr,omitempty"`
+	CUR            *AwsAccountCUR           `json:"cur,omitempty"`
+}
+
+// AccountTag is an arbitrary key/value label used for perspective assignment.
+type AccountTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// AwsAccountBilling configures where CloudHealth finds the account's monthly billing reports.
+type AwsAccountBilling struct {
+	Bucket string `json:"bucket,omitempty"`
+}
+
+// AwsAccountCloudTrail configures ingestion of the account's CloudTrail logs.
+type AwsAccountCloudTrail struct {
+	Enabled bool   `json:"enabled"`
+	Bucket  string `json:"bucket,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// AwsAccountConfig configures ingestion of the account's AWS Config snapshots.
+type AwsAccountConfig struct {
+	Enabled bool   `json:"enabled"`
+	Bucket  string `json:"bucket,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// AwsAccountDBR configures where CloudHealth finds the account's Detailed Billing Reports.
+type AwsAccountDBR struct {
+	Bucket string `json:"bucket,omitempty"`
+}
+
+// AwsAccountCUR configures where CloudHealth finds the account's Cost and Usage Reports.
+type AwsAccountCUR struct {
+	Bucket string `json:"bucket,omitempty"`
 }
 
 // AwsAccounts is a structure to unmarshal CloudHealth GET accounts results into
 type AwsAccounts struct {
 	Accounts []AwsAccount `json:"aws_accounts"`
+	// NextPageToken is set by CloudHealth when more results are available via
+	// cursor-based pagination. When absent from the first page, callers fall back to
+	// page numbers; when absent from a token-paginated page, the listing is complete.
+	NextPageToken string `json:"next_page_token,omitempty"`
 }
 
 // AwsAccountAuthentication represents the authentication details for AWS integration.
@@ -37,226 +82,196 @@ type AwsAccountAuthentication struct {
 // It's useful for ignoring errors (e.g. delete if exists).
 var ErrAwsAccountNotFound = errors.New("AWS Account not found")
 
-// getPaginatedAwsAccounts retrieves a page of results for the GetAllAwsAccounts function
-func getPaginatedAwsAccounts(client *http.Client, req *http.Request, page, perPage int) (*AwsAccounts, error) {
-	var accountsPage = new(AwsAccounts)
+// AwsAccountIterator streams through the aws_accounts listing one account at a time,
+// fetching pages lazily so callers never hold the full tenant in memory at once. It
+// prefers CloudHealth's page_token cursor when the API returns one, falling back to
+// page-number pagination otherwise.
+type AwsAccountIterator struct {
+	client  *Client
+	perPage int
+
+	buf  []AwsAccount
+	pos  int
+	page int
+
+	nextToken  string
+	usingToken bool
+	done       bool
+}
 
-	q := req.URL.Query()
-	q.Set("per_page", strconv.Itoa(perPage))
-	q.Set("page", strconv.Itoa(page))
-	req.URL.RawQuery = q.Encode()
+// NewAwsAccountIterator creates an iterator over the aws_accounts listing, perPage accounts at a time.
+func (s *Client) NewAwsAccountIterator(perPage int) *AwsAccountIterator {
+	return &AwsAccountIterator{client: s, perPage: perPage}
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+// Next returns the next AwsAccount, or io.EOF once the listing is exhausted.
+func (it *AwsAccountIterator) Next(ctx context.Context) (*AwsAccount, error) {
+	for it.pos >= len(it.buf) {
+		if it.done {
+			return nil, io.EOF
+		}
+		if err := it.fetch(ctx); err != nil {
+			return nil, err
+		}
 	}
-	defer resp.Body.Close()
+	account := &it.buf[it.pos]
+	it.pos++
+	return account, nil
+}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
+// fetch retrieves the next page into the iterator's buffer.
+func (it *AwsAccountIterator) fetch(ctx context.Context) error {
+	q := url.Values{}
+	q.Set("per_page", strconv.Itoa(it.perPage))
+	if it.usingToken {
+		// Opaque cursor tokens are frequently base64 and may contain '+', '&', '=' or
+		// '%'; q.Encode() percent-encodes those so they survive do()'s re-parsing of
+		// the path, whereas hand-formatting the query string would not.
+		q.Set("page_token", it.nextToken)
+	} else {
+		it.page++
+		q.Set("page", strconv.Itoa(it.page))
+	}
+	path := "aws_accounts?" + q.Encode()
+
+	var accountsPage AwsAccounts
+	err := it.client.do(ctx, http.MethodGet, path, nil, &accountsPage, ErrAwsAccountNotFound, http.StatusOK)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(responseBody, &accountsPage)
-		if err != nil {
-			return nil, err
-		}
-		return accountsPage, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, ErrAwsAccountNotFound
-	default:
-		return nil, fmt.Errorf("Unknown Response from CloudHealth: `%d`", resp.StatusCode)
+	it.buf = accountsPage.Accounts
+	it.pos = 0
+
+	switch {
+	case accountsPage.NextPageToken != "":
+		it.usingToken = true
+		it.nextToken = accountsPage.NextPageToken
+	case len(accountsPage.Accounts) < it.perPage:
+		it.done = true
+	case it.usingToken:
+		// A token-paginated response with no further token means this was the last page.
+		it.done = true
 	}
+	return nil
 }
 
 // GetAllAwsAccounts gets all AWS Accounts
 func (s *Client) GetAllAwsAccounts(perPage int) ([]AwsAccount, error) {
-	var accounts []AwsAccount
+	return s.GetAllAwsAccountsContext(context.Background(), perPage)
+}
 
-	// Establish our HTTP client
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts?api_key=%s", s.ApiKey))
-	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
-	req, err := http.NewRequest("GET", apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
+// GetAllAwsAccountsContext gets all AWS Accounts, observing ctx cancellation across pages.
+// It drains a AwsAccountIterator for backward compatibility; prefer NewAwsAccountIterator
+// directly when iterating over very large tenants.
+func (s *Client) GetAllAwsAccountsContext(ctx context.Context, perPage int) ([]AwsAccount, error) {
+	var accounts []AwsAccount
 
-	// Get Paginated results for AWS accounts endpoint
-	// CloudHealth starts counting pages at 1 (but also accepts 0 which has results identical to 1)
-	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
-		accountsPage, err := getPaginatedAwsAccounts(client, req, pageNo, perPage)
+	it := s.NewAwsAccountIterator(perPage)
+	for {
+		account, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
 			return nil, err
 		}
-		accounts = append(accounts, accountsPage.Accounts...)
-		pageLen = len(accountsPage.Accounts)
-	}
-	if err != nil {
-		return nil, err
+		accounts = append(accounts, *account)
 	}
 	return accounts, nil
 }
 
 // GetAwsAccount gets the AWS Account with the specified CloudHealth Account ID.
 func (s *Client) GetAwsAccount(id int) (*AwsAccount, error) {
+	return s.GetAwsAccountContext(context.Background(), id)
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d?api_key=%s", id, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("GET", url.String(), nil)
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
+// GetAwsAccountContext gets the AWS Account with the specified CloudHealth Account ID.
+func (s *Client) GetAwsAccountContext(ctx context.Context, id int) (*AwsAccount, error) {
+	var account AwsAccount
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("aws_accounts/%d", id), nil, &account, ErrAwsAccountNotFound, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var account = new(AwsAccount)
-		err = json.Unmarshal(responseBody, &account)
-		if err != nil {
-			return nil, err
-		}
-
-		return account, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, ErrAwsAccountNotFound
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+	return &account, nil
 }
 
 // CreateAwsAccount enables a new AWS Account in CloudHealth.
 func (s *Client) CreateAwsAccount(account AwsAccount) (*AwsAccount, error) {
+	return s.CreateAwsAccountContext(context.Background(), account)
+}
 
-	body, _ := json.Marshal(account)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts?api_key=%s", s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
+// CreateAwsAccountContext enables a new AWS Account in CloudHealth.
+func (s *Client) CreateAwsAccountContext(ctx context.Context, account AwsAccount) (*AwsAccount, error) {
+	var created AwsAccount
+	err := s.do(ctx, http.MethodPost, "aws_accounts", account, &created, nil, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
-
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var account = new(AwsAccount)
-		err = json.Unmarshal(responseBody, &account)
-		if err != nil {
-			return nil, err
-		}
-
-		return account, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusUnprocessableEntity:
-		return nil, fmt.Errorf("Bad Request. Please check if a AWS Account with this name `%s` already exists", account.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+	return &created, nil
 }
 
 // UpdateAwsAccount updates an existing AWS Account in CloudHealth.
 func (s *Client) UpdateAwsAccount(account AwsAccount) (*AwsAccount, error) {
+	return s.UpdateAwsAccountContext(context.Background(), account)
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d?api_key=%s", account.ID, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	body, _ := json.Marshal(account)
-
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
+// UpdateAwsAccountContext updates an existing AWS Account in CloudHealth.
+func (s *Client) UpdateAwsAccountContext(ctx context.Context, account AwsAccount) (*AwsAccount, error) {
+	var updated AwsAccount
+	path := fmt.Sprintf("aws_accounts/%d", account.ID)
+	err := s.do(ctx, http.MethodPut, path, account, &updated, ErrAwsAccountNotFound, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var account = new(AwsAccount)
-		err = json.Unmarshal(responseBody, &account)
-		if err != nil {
-			return nil, err
-		}
-
-		return account, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusUnprocessableEntity:
-		return nil, fmt.Errorf("Bad Request. Please check if a AWS Account with this name `%s` already exists", account.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+	return &updated, nil
 }
 
 // DeleteAwsAccount removes the AWS Account with the specified CloudHealth ID.
 func (s *Client) DeleteAwsAccount(id int) error {
+	return s.DeleteAwsAccountContext(context.Background(), id)
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d?api_key=%s", id, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
+// DeleteAwsAccountContext removes the AWS Account with the specified CloudHealth ID.
+func (s *Client) DeleteAwsAccountContext(ctx context.Context, id int) error {
+	path := fmt.Sprintf("aws_accounts/%d", id)
+	return s.do(ctx, http.MethodDelete, path, nil, nil, ErrAwsAccountNotFound, http.StatusOK, http.StatusNoContent)
+}
 
-	req, err := http.NewRequest("DELETE", url.String(), nil)
+// GetAwsAccountTags gets the tags assigned to the AWS Account with the specified CloudHealth ID.
+func (s *Client) GetAwsAccountTags(id int) ([]AccountTag, error) {
+	return s.GetAwsAccountTagsContext(context.Background(), id)
+}
 
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
+// GetAwsAccountTagsContext gets the tags assigned to the AWS Account with the specified CloudHealth ID.
+func (s *Client) GetAwsAccountTagsContext(ctx context.Context, id int) ([]AccountTag, error) {
+	var tags []AccountTag
+	path := fmt.Sprintf("aws_accounts/%d/tags", id)
+	err := s.do(ctx, http.MethodGet, path, nil, &tags, ErrAwsAccountNotFound, http.StatusOK)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return nil
-	case http.StatusNoContent:
-		return nil
-	case http.StatusNotFound:
-		return ErrAwsAccountNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
+	return tags, nil
+}
+
+// SetAwsAccountTags replaces the tags assigned to the AWS Account with the specified
+// CloudHealth ID, without requiring callers to read and resend the full account payload.
+func (s *Client) SetAwsAccountTags(id int, tags []AccountTag) error {
+	return s.SetAwsAccountTagsContext(context.Background(), id, tags)
+}
+
+// SetAwsAccountTagsContext replaces the tags assigned to the AWS Account with the
+// specified CloudHealth ID, without requiring callers to read and resend the full
+// account payload.
+func (s *Client) SetAwsAccountTagsContext(ctx context.Context, id int, tags []AccountTag) error {
+	if tags == nil {
+		// json.Marshal encodes a nil slice as the literal `null`, but the tags
+		// endpoint's request body *is* the tag list, so there's no omitempty field
+		// to absorb it. Clearing an account's tags is the natural empty case (it's
+		// exactly the reconciliation use case this helper exists for), so normalize
+		// to an empty array rather than sending `null`.
+		tags = []AccountTag{}
 	}
+	path := fmt.Sprintf("aws_accounts/%d/tags", id)
+	return s.do(ctx, http.MethodPut, path, tags, nil, ErrAwsAccountNotFound, http.StatusOK, http.StatusNoContent)
 }
@@ -0,0 +1,172 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newAwsAccountTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	endpoint, err := url.Parse(baseURL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	return &Client{ApiKey: "test-key", EndpointURL: endpoint, Timeout: 5}
+}
+
+// TestAwsAccountIteratorExactMultipleOfPerPage covers the edge case the backlog called
+// out as fragile: a tenant whose account count is an exact multiple of perPage must
+// still terminate once the following page comes back empty, rather than looping forever
+// or incorrectly treating the full last page as the final one.
+func TestAwsAccountIteratorExactMultipleOfPerPage(t *testing.T) {
+	pages := [][]AwsAccount{
+		{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+		{}, // exact multiple of perPage (2): the next page is empty
+	}
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		idx := 0
+		if page == "2" {
+			idx = 1
+		}
+		requests++
+		json.NewEncoder(w).Encode(AwsAccounts{Accounts: pages[idx]})
+	}))
+	defer server.Close()
+
+	client := newAwsAccountTestClient(t, server.URL)
+	it := client.NewAwsAccountIterator(2)
+
+	var got []AwsAccount
+	for {
+		account, err := it.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		got = append(got, *account)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 accounts, got %d", len(got))
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 page requests, got %d", requests)
+	}
+}
+
+// TestAwsAccountIteratorPrefersPageToken verifies the iterator switches to cursor-based
+// pagination as soon as CloudHealth returns a next_page_token, and terminates cleanly
+// once a token-paginated response omits it, including when the cursor contains
+// characters ('+') that must be percent-encoded to round-trip correctly.
+func TestAwsAccountIteratorPrefersPageToken(t *testing.T) {
+	const cursor = "abc+def/==" // '+' must survive percent-encoding round trip
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("page_token")
+		switch token {
+		case "":
+			json.NewEncoder(w).Encode(AwsAccounts{
+				Accounts:      []AwsAccount{{ID: 1, Name: "first-page"}},
+				NextPageToken: cursor,
+			})
+		case cursor:
+			json.NewEncoder(w).Encode(AwsAccounts{Accounts: []AwsAccount{{ID: 2, Name: "second-page"}}})
+		default:
+			t.Errorf("unexpected page_token %q", token)
+		}
+	}))
+	defer server.Close()
+
+	client := newAwsAccountTestClient(t, server.URL)
+	it := client.NewAwsAccountIterator(10)
+
+	var names []string
+	for {
+		account, err := it.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		names = append(names, account.Name)
+	}
+
+	if len(names) != 2 || names[0] != "first-page" || names[1] != "second-page" {
+		t.Fatalf("unexpected accounts returned: %v", names)
+	}
+}
+
+// TestGetAwsAccountTagsContext verifies the tags endpoint's response body is decoded
+// directly into a []AccountTag, with no wrapping envelope.
+func TestGetAwsAccountTagsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/aws_accounts/1/tags" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode([]AccountTag{{Key: "env", Value: "prod"}})
+	}))
+	defer server.Close()
+
+	client := newAwsAccountTestClient(t, server.URL)
+	tags, err := client.GetAwsAccountTagsContext(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetAwsAccountTagsContext returned error: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Key != "env" || tags[0].Value != "prod" {
+		t.Fatalf("unexpected tags: %+v", tags)
+	}
+}
+
+// TestSetAwsAccountTagsContext covers both the populated and clear-tags cases. The
+// clear case (nil tags) is the one that regressed: json.Marshal encodes a nil slice
+// as the literal `null`, which CloudHealth's tags endpoint would reject or
+// misinterpret instead of clearing the account's tags.
+func TestSetAwsAccountTagsContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []AccountTag
+		wantBody string
+	}{
+		{name: "populated", tags: []AccountTag{{Key: "env", Value: "prod"}}, wantBody: `[{"key":"env","value":"prod"}]`},
+		{name: "nil clears to empty array", tags: nil, wantBody: "[]"},
+		{name: "empty slice stays empty array", tags: []AccountTag{}, wantBody: "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/aws_accounts/1/tags" {
+					t.Errorf("unexpected path %q", r.URL.Path)
+				}
+				if r.Method != http.MethodPut {
+					t.Errorf("unexpected method %q", r.Method)
+				}
+				raw, _ := ioutil.ReadAll(r.Body)
+				gotBody = string(raw)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := newAwsAccountTestClient(t, server.URL)
+			if err := client.SetAwsAccountTagsContext(context.Background(), 1, tt.tags); err != nil {
+				t.Fatalf("SetAwsAccountTagsContext returned error: %v", err)
+			}
+			if gotBody != tt.wantBody {
+				t.Fatalf("expected request body %q, got %q", tt.wantBody, gotBody)
+			}
+		})
+	}
+}
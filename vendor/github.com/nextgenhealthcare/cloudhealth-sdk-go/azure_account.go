@@ -0,0 +1,118 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AzureAccount represents the configuration of an Azure Subscription enabled in CloudHealth.
+type AzureAccount struct {
+	ID             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	Authentication AzureAccountAuthentication `json:"authentication"`
+}
+
+// AzureAccounts is a structure to unmarshal CloudHealth GET subscriptions results into
+type AzureAccounts struct {
+	Accounts []AzureAccount `json:"azure_accounts"`
+}
+
+// AzureAccountAuthentication represents the authentication details for Azure integration.
+type AzureAccountAuthentication struct {
+	Protocol       string `json:"protocol"`
+	TenantID       string `json:"tenant_id,omitempty"`
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	ApplicationID  string `json:"application_id,omitempty"`
+	SecretKey      string `json:"secret_key,omitempty"`
+}
+
+// ErrAzureAccountNotFound is returned when an Azure Account doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrAzureAccountNotFound = errors.New("Azure Account not found")
+
+// GetAllAzureAccounts gets all Azure Accounts
+func (s *Client) GetAllAzureAccounts(perPage int) ([]AzureAccount, error) {
+	return s.GetAllAzureAccountsContext(context.Background(), perPage)
+}
+
+// GetAllAzureAccountsContext gets all Azure Accounts, observing ctx cancellation across pages.
+func (s *Client) GetAllAzureAccountsContext(ctx context.Context, perPage int) ([]AzureAccount, error) {
+	var accounts []AzureAccount
+
+	// CloudHealth starts counting pages at 1 (but also accepts 0 which has results identical to 1)
+	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
+		q := url.Values{}
+		q.Set("per_page", strconv.Itoa(perPage))
+		q.Set("page", strconv.Itoa(pageNo))
+
+		var accountsPage AzureAccounts
+		err := s.do(ctx, http.MethodGet, "azure_accounts?"+q.Encode(), nil, &accountsPage, ErrAzureAccountNotFound, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, accountsPage.Accounts...)
+		pageLen = len(accountsPage.Accounts)
+	}
+	return accounts, nil
+}
+
+// GetAzureAccount gets the Azure Account with the specified CloudHealth Account ID.
+func (s *Client) GetAzureAccount(id int) (*AzureAccount, error) {
+	return s.GetAzureAccountContext(context.Background(), id)
+}
+
+// GetAzureAccountContext gets the Azure Account with the specified CloudHealth Account ID.
+func (s *Client) GetAzureAccountContext(ctx context.Context, id int) (*AzureAccount, error) {
+	var account AzureAccount
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("azure_accounts/%d", id), nil, &account, ErrAzureAccountNotFound, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateAzureAccount enables a new Azure Account in CloudHealth.
+func (s *Client) CreateAzureAccount(account AzureAccount) (*AzureAccount, error) {
+	return s.CreateAzureAccountContext(context.Background(), account)
+}
+
+// CreateAzureAccountContext enables a new Azure Account in CloudHealth.
+func (s *Client) CreateAzureAccountContext(ctx context.Context, account AzureAccount) (*AzureAccount, error) {
+	var created AzureAccount
+	err := s.do(ctx, http.MethodPost, "azure_accounts", account, &created, nil, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateAzureAccount updates an existing Azure Account in CloudHealth.
+func (s *Client) UpdateAzureAccount(account AzureAccount) (*AzureAccount, error) {
+	return s.UpdateAzureAccountContext(context.Background(), account)
+}
+
+// UpdateAzureAccountContext updates an existing Azure Account in CloudHealth.
+func (s *Client) UpdateAzureAccountContext(ctx context.Context, account AzureAccount) (*AzureAccount, error) {
+	var updated AzureAccount
+	path := fmt.Sprintf("azure_accounts/%d", account.ID)
+	err := s.do(ctx, http.MethodPut, path, account, &updated, ErrAzureAccountNotFound, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteAzureAccount removes the Azure Account with the specified CloudHealth ID.
+func (s *Client) DeleteAzureAccount(id int) error {
+	return s.DeleteAzureAccountContext(context.Background(), id)
+}
+
+// DeleteAzureAccountContext removes the Azure Account with the specified CloudHealth ID.
+func (s *Client) DeleteAzureAccountContext(ctx context.Context, id int) error {
+	path := fmt.Sprintf("azure_accounts/%d", id)
+	return s.do(ctx, http.MethodDelete, path, nil, nil, ErrAzureAccountNotFound, http.StatusOK, http.StatusNoContent)
+}
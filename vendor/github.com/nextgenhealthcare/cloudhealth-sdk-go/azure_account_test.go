@@ -0,0 +1,67 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newAzureAccountTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	endpoint, err := url.Parse(baseURL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	return &Client{ApiKey: "test-key", EndpointURL: endpoint, Timeout: 5}
+}
+
+// TestGetAllAzureAccountsContextPaginates verifies GetAllAzureAccountsContext keeps
+// requesting pages until a short page tells it there's nothing more to fetch.
+func TestGetAllAzureAccountsContextPaginates(t *testing.T) {
+	pages := [][]AzureAccount{
+		{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+		{{ID: 3, Name: "c"}},
+	}
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		idx := 0
+		if page == "2" {
+			idx = 1
+		}
+		requests++
+		json.NewEncoder(w).Encode(AzureAccounts{Accounts: pages[idx]})
+	}))
+	defer server.Close()
+
+	client := newAzureAccountTestClient(t, server.URL)
+	accounts, err := client.GetAllAzureAccountsContext(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAllAzureAccountsContext returned error: %v", err)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("expected 3 accounts, got %d", len(accounts))
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 page requests, got %d", requests)
+	}
+}
+
+// TestGetAzureAccountContextNotFound verifies a 404 from CloudHealth surfaces as
+// ErrAzureAccountNotFound rather than a generic APIError.
+func TestGetAzureAccountContextNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newAzureAccountTestClient(t, server.URL)
+	_, err := client.GetAzureAccountContext(context.Background(), 1)
+	if err != ErrAzureAccountNotFound {
+		t.Fatalf("expected ErrAzureAccountNotFound, got %v", err)
+	}
+}
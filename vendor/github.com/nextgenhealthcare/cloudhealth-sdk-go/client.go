@@ -0,0 +1,256 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Client is a CloudHealth API client. It holds the credentials and endpoint needed to
+// reach the API, along with the HTTP transport and retry policy shared by every method.
+type Client struct {
+	ApiKey      string
+	EndpointURL *url.URL
+	Timeout     int64
+
+	// RetryPolicy decides whether a request should be retried given its response
+	// (which may be nil on a transport error) and the error returned, if any.
+	// Defaults to DefaultRetryPolicy when nil.
+	RetryPolicy RetryPolicy
+	// MaxRetries caps the number of retry attempts after the initial request.
+	// Defaults to 3 when zero.
+	MaxRetries int
+	// BaseBackoff is the starting delay for exponential backoff between retries.
+	// Defaults to 500ms when zero.
+	BaseBackoff time.Duration
+
+	httpClientOnce sync.Once
+	httpClient     *http.Client
+}
+
+// RetryPolicy decides whether a request should be retried. resp is nil when err is a
+// transport-level error (e.g. connection reset).
+type RetryPolicy func(resp *http.Response, err error) bool
+
+// DefaultRetryPolicy retries on connection errors, CloudHealth's 429 Too Many Requests,
+// and 5xx responses to idempotent verbs (GET/PUT/DELETE).
+func DefaultRetryPolicy(method string) RetryPolicy {
+	return func(resp *http.Response, err error) bool {
+		if err != nil {
+			return true
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+		if resp.StatusCode >= 500 && (method == "GET" || method == "PUT" || method == "DELETE") {
+			return true
+		}
+		return false
+	}
+}
+
+// ErrClientAuthenticationError is returned when CloudHealth rejects the configured API key.
+var ErrClientAuthenticationError = errors.New("Unable to authenticate with CloudHealth, check your API Key")
+
+// client returns the *http.Client shared across requests, lazily creating it on first use
+// so existing callers that build a Client by hand don't need to set one explicitly. A
+// Client is shared across concurrent Terraform resource operations, so construction is
+// guarded by httpClientOnce rather than a plain nil-check.
+func (s *Client) client() *http.Client {
+	s.httpClientOnce.Do(func() {
+		s.httpClient = &http.Client{
+			Timeout: time.Second * time.Duration(s.Timeout),
+		}
+	})
+	return s.httpClient
+}
+
+// maxRetries returns the configured retry cap, defaulting to 3.
+func (s *Client) maxRetries() int {
+	if s.MaxRetries == 0 {
+		return 3
+	}
+	return s.MaxRetries
+}
+
+// baseBackoff returns the configured base backoff, defaulting to 500ms.
+func (s *Client) baseBackoff() time.Duration {
+	if s.BaseBackoff == 0 {
+		return 500 * time.Millisecond
+	}
+	return s.BaseBackoff
+}
+
+// doWithRetry executes req, retrying according to the Client's RetryPolicy (or
+// DefaultRetryPolicy for req.Method when unset) with capped exponential backoff and
+// jitter. A 429 response's Retry-After header, when present, overrides the computed
+// backoff for that attempt. ctx cancellation aborts the wait between retries.
+//
+// req.Body is drained by the first attempt, so any retry must rebuild it from
+// req.GetBody (populated automatically by http.NewRequest for *bytes.Buffer,
+// *bytes.Reader and *strings.Reader bodies). If a retry is warranted but GetBody is
+// nil, doWithRetry fails fast rather than resend a now-empty body.
+func (s *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	policy := s.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy(req.Method)
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		resp, err = s.client().Do(req.WithContext(ctx))
+		if attempt >= s.maxRetries() || !policy(resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, fmt.Errorf("cloudhealth: cannot retry %s %s: request body cannot be rewound", req.Method, req.URL.Path)
+		}
+
+		wait := retryAfter(resp)
+		if wait == 0 {
+			wait = s.baseBackoff() * time.Duration(math.Pow(2, float64(attempt)))
+			wait += time.Duration(rand.Int63n(int64(s.baseBackoff())))
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter parses a 429 response's Retry-After header (seconds), returning 0 when
+// absent or not a 429.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ValidationError represents CloudHealth's 422 response to a request that failed
+// validation, e.g. `{"errors": {"name": ["has already been taken"]}}`.
+type ValidationError struct {
+	Errors map[string]interface{} `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("CloudHealth rejected the request as invalid: %v", e.Errors)
+}
+
+// APIError represents an unexpected response from CloudHealth that isn't covered by a
+// more specific sentinel or typed error, most commonly a 5xx.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Unknown Response from CloudHealth: `%d` (%s %s): %s", e.StatusCode, e.Method, e.Path, e.Body)
+}
+
+// do is the shared HTTP transport behind every Client method. It resolves path against
+// EndpointURL, signs the request with ApiKey, JSON-encodes body (when non-nil) as the
+// request payload, and JSON-decodes the response into out (when non-nil) on any status
+// in successCodes. On failure it maps 401 to ErrClientAuthenticationError, 404 to
+// notFound (when supplied), 422 to a *ValidationError, and anything else to a *APIError
+// carrying the status, method, path and raw response body.
+func (s *Client) do(ctx context.Context, method, path string, body, out interface{}, notFound error, successCodes ...int) error {
+	relativeURL, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
+	q := apiUrl.Query()
+	q.Set("api_key", s.ApiKey)
+	apiUrl.RawQuery = q.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewBuffer(encoded)
+	}
+
+	req, err := http.NewRequest(method, apiUrl.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	resp, err := s.doWithRetry(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	for _, code := range successCodes {
+		if resp.StatusCode != code {
+			continue
+		}
+		if out == nil || len(responseBody) == 0 {
+			return nil
+		}
+		return json.Unmarshal(responseBody, out)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrClientAuthenticationError
+	case http.StatusNotFound:
+		if notFound != nil {
+			return notFound
+		}
+		return &APIError{StatusCode: resp.StatusCode, Method: method, Path: path, Body: responseBody}
+	case http.StatusUnprocessableEntity:
+		var validationErr ValidationError
+		if err := json.Unmarshal(responseBody, &validationErr); err != nil {
+			return &APIError{StatusCode: resp.StatusCode, Method: method, Path: path, Body: responseBody}
+		}
+		return &validationErr
+	default:
+		return &APIError{StatusCode: resp.StatusCode, Method: method, Path: path, Body: responseBody}
+	}
+}
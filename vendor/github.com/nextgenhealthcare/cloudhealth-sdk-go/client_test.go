@@ -0,0 +1,123 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	endpoint, err := url.Parse(baseURL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	return &Client{
+		ApiKey:      "test-key",
+		EndpointURL: endpoint,
+		Timeout:     5,
+		BaseBackoff: time.Millisecond, // keep the test fast; doWithRetry still exercises the retry path
+	}
+}
+
+func TestDoWithRetryRewindsBodyOnRetry(t *testing.T) {
+	var attempts int
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		raw, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(raw))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1,"name":"acme"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	var created AwsAccount
+	err := client.do(context.Background(), http.MethodPost, "aws_accounts", AwsAccount{Name: "acme"}, &created, nil, http.StatusCreated)
+	if err != nil {
+		t.Fatalf("do returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Fatalf("expected identical request bodies across retries, got %q and %q", bodies[0], bodies[1])
+	}
+	if created.Name != "acme" {
+		t.Fatalf("expected decoded account name 'acme', got %q", created.Name)
+	}
+}
+
+func TestDoWithRetryFailsFastWhenBodyCannotBeRewound(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		io.Copy(ioutil.Discard, r.Body)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	encoded, _ := json.Marshal(AwsAccount{Name: "acme"})
+	// Wrapping the reader hides it from http.NewRequest's *bytes.Buffer/*bytes.Reader/
+	// *strings.Reader special-casing, so req.GetBody is left nil, exactly like a
+	// caller-supplied io.Reader that can't be replayed.
+	unrewindable := struct{ io.Reader }{bytes.NewReader(encoded)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/aws_accounts", unrewindable)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	if req.GetBody != nil {
+		t.Fatalf("test precondition failed: req.GetBody should be nil")
+	}
+
+	_, err = client.doWithRetry(context.Background(), req)
+	if err == nil {
+		t.Fatal("expected doWithRetry to fail fast, got nil error")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt before failing fast, got %d", attempts)
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		status int
+		retry  bool
+	}{
+		{"429 on GET retries", http.MethodGet, http.StatusTooManyRequests, true},
+		{"429 on POST retries", http.MethodPost, http.StatusTooManyRequests, true},
+		{"5xx on GET retries", http.MethodGet, http.StatusServiceUnavailable, true},
+		{"5xx on POST does not retry", http.MethodPost, http.StatusServiceUnavailable, false},
+		{"404 does not retry", http.MethodGet, http.StatusNotFound, false},
+		{"200 does not retry", http.MethodGet, http.StatusOK, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := DefaultRetryPolicy(tc.method)
+			resp := &http.Response{StatusCode: tc.status}
+			if got := policy(resp, nil); got != tc.retry {
+				t.Fatalf("policy(%s, %d) = %v, want %v", tc.method, tc.status, got, tc.retry)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GcpAccount represents the configuration of a GCP Project enabled in CloudHealth.
+type GcpAccount struct {
+	ID             int                      `json:"id"`
+	Name           string                   `json:"name"`
+	Authentication GcpAccountAuthentication `json:"authentication"`
+}
+
+// GcpAccounts is a structure to unmarshal CloudHealth GET projects results into
+type GcpAccounts struct {
+	Accounts []GcpAccount `json:"gcp_accounts"`
+}
+
+// GcpAccountAuthentication represents the authentication details for GCP integration.
+type GcpAccountAuthentication struct {
+	Protocol          string `json:"protocol"`
+	ServiceAccountKey string `json:"service_account_key,omitempty"`
+	ProjectID         string `json:"project_id,omitempty"`
+}
+
+// ErrGcpAccountNotFound is returned when a GCP Account doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrGcpAccountNotFound = errors.New("GCP Account not found")
+
+// GetAllGcpAccounts gets all GCP Accounts
+func (s *Client) GetAllGcpAccounts(perPage int) ([]GcpAccount, error) {
+	return s.GetAllGcpAccountsContext(context.Background(), perPage)
+}
+
+// GetAllGcpAccountsContext gets all GCP Accounts, observing ctx cancellation across pages.
+func (s *Client) GetAllGcpAccountsContext(ctx context.Context, perPage int) ([]GcpAccount, error) {
+	var accounts []GcpAccount
+
+	// CloudHealth starts counting pages at 1 (but also accepts 0 which has results identical to 1)
+	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
+		q := url.Values{}
+		q.Set("per_page", strconv.Itoa(perPage))
+		q.Set("page", strconv.Itoa(pageNo))
+
+		var accountsPage GcpAccounts
+		err := s.do(ctx, http.MethodGet, "gcp_accounts?"+q.Encode(), nil, &accountsPage, ErrGcpAccountNotFound, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, accountsPage.Accounts...)
+		pageLen = len(accountsPage.Accounts)
+	}
+	return accounts, nil
+}
+
+// GetGcpAccount gets the GCP Account with the specified CloudHealth Account ID.
+func (s *Client) GetGcpAccount(id int) (*GcpAccount, error) {
+	return s.GetGcpAccountContext(context.Background(), id)
+}
+
+// GetGcpAccountContext gets the GCP Account with the specified CloudHealth Account ID.
+func (s *Client) GetGcpAccountContext(ctx context.Context, id int) (*GcpAccount, error) {
+	var account GcpAccount
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("gcp_accounts/%d", id), nil, &account, ErrGcpAccountNotFound, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateGcpAccount enables a new GCP Account in CloudHealth.
+func (s *Client) CreateGcpAccount(account GcpAccount) (*GcpAccount, error) {
+	return s.CreateGcpAccountContext(context.Background(), account)
+}
+
+// CreateGcpAccountContext enables a new GCP Account in CloudHealth.
+func (s *Client) CreateGcpAccountContext(ctx context.Context, account GcpAccount) (*GcpAccount, error) {
+	var created GcpAccount
+	err := s.do(ctx, http.MethodPost, "gcp_accounts", account, &created, nil, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateGcpAccount updates an existing GCP Account in CloudHealth.
+func (s *Client) UpdateGcpAccount(account GcpAccount) (*GcpAccount, error) {
+	return s.UpdateGcpAccountContext(context.Background(), account)
+}
+
+// UpdateGcpAccountContext updates an existing GCP Account in CloudHealth.
+func (s *Client) UpdateGcpAccountContext(ctx context.Context, account GcpAccount) (*GcpAccount, error) {
+	var updated GcpAccount
+	path := fmt.Sprintf("gcp_accounts/%d", account.ID)
+	err := s.do(ctx, http.MethodPut, path, account, &updated, ErrGcpAccountNotFound, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteGcpAccount removes the GCP Account with the specified CloudHealth ID.
+func (s *Client) DeleteGcpAccount(id int) error {
+	return s.DeleteGcpAccountContext(context.Background(), id)
+}
+
+// DeleteGcpAccountContext removes the GCP Account with the specified CloudHealth ID.
+func (s *Client) DeleteGcpAccountContext(ctx context.Context, id int) error {
+	path := fmt.Sprintf("gcp_accounts/%d", id)
+	return s.do(ctx, http.MethodDelete, path, nil, nil, ErrGcpAccountNotFound, http.StatusOK, http.StatusNoContent)
+}
@@ -0,0 +1,67 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newGcpAccountTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	endpoint, err := url.Parse(baseURL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	return &Client{ApiKey: "test-key", EndpointURL: endpoint, Timeout: 5}
+}
+
+// TestGetAllGcpAccountsContextPaginates verifies GetAllGcpAccountsContext keeps
+// requesting pages until a short page tells it there's nothing more to fetch.
+func TestGetAllGcpAccountsContextPaginates(t *testing.T) {
+	pages := [][]GcpAccount{
+		{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+		{{ID: 3, Name: "c"}},
+	}
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		idx := 0
+		if page == "2" {
+			idx = 1
+		}
+		requests++
+		json.NewEncoder(w).Encode(GcpAccounts{Accounts: pages[idx]})
+	}))
+	defer server.Close()
+
+	client := newGcpAccountTestClient(t, server.URL)
+	accounts, err := client.GetAllGcpAccountsContext(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAllGcpAccountsContext returned error: %v", err)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("expected 3 accounts, got %d", len(accounts))
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 page requests, got %d", requests)
+	}
+}
+
+// TestGetGcpAccountContextNotFound verifies a 404 from CloudHealth surfaces as
+// ErrGcpAccountNotFound rather than a generic APIError.
+func TestGetGcpAccountContextNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newGcpAccountTestClient(t, server.URL)
+	_, err := client.GetGcpAccountContext(context.Background(), 1)
+	if err != ErrGcpAccountNotFound {
+		t.Fatalf("expected ErrGcpAccountNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Perspective constant kinds recognized by the CloudHealth Perspectives API.
+const (
+	ConstantKindStaticGroup       = "Static Group"
+	ConstantKindDynamicGroup      = "Dynamic Group"
+	ConstantKindDynamicGroupBlock = "Dynamic Group Block"
+)
+
+// Perspective represents a CloudHealth Perspective used to group accounts for cost/reporting.
+type Perspective struct {
+	ID     string            `json:"id,omitempty"`
+	Name   string            `json:"name"`
+	Schema PerspectiveSchema `json:"schema"`
+}
+
+// Perspectives is a structure to unmarshal CloudHealth GET perspectives results into
+type Perspectives struct {
+	Perspectives []Perspective `json:"perspectives"`
+}
+
+// PerspectiveSchema captures the name, merges, constants and rules of a Perspective.
+// Field ordering here matches the order CloudHealth expects on the wire; Constants and
+// Rules are marshaled as JSON arrays so their ordering is preserved deterministically.
+type PerspectiveSchema struct {
+	Name      string                `json:"name"`
+	Merges    []PerspectiveMerge    `json:"merges,omitempty"`
+	Constants []PerspectiveConstant `json:"constants,omitempty"`
+	Rules     []PerspectiveRule     `json:"rules,omitempty"`
+}
+
+// PerspectiveMerge references two constant groups that should be merged into one.
+type PerspectiveMerge struct {
+	Name string   `json:"name"`
+	Is   []string `json:"is"`
+}
+
+// PerspectiveConstant defines a named group of values within a Perspective, e.g. a
+// Static Group, Dynamic Group, or Dynamic Group Block (see the ConstantKind* constants).
+type PerspectiveConstant struct {
+	Type string          `json:"type"`
+	List []ConstantValue `json:"list,omitempty"`
+	Name string          `json:"name,omitempty"`
+	Ref  string          `json:"ref_id,omitempty"`
+}
+
+// ConstantValue is a single named value within a PerspectiveConstant's list.
+type ConstantValue struct {
+	Name string `json:"name"`
+	Ref  string `json:"ref_id"`
+}
+
+// PerspectiveRule represents one rule block (categorize, filter, or group) applied
+// while building the Perspective. `Type` names which of the three blocks the rule is.
+type PerspectiveRule struct {
+	Type          string  `json:"type"`
+	To            string  `json:"to,omitempty"`
+	Asset         string  `json:"asset,omitempty"`
+	Clause        *Clause `json:"condition,omitempty"`
+	CategoryRefID string  `json:"ref_id,omitempty"`
+}
+
+// Clause is a boolean combination of Conditions and/or Tags used by filter/categorize rules.
+type Clause struct {
+	And  []Clause   `json:"and,omitempty"`
+	Or   []Clause   `json:"or,omitempty"`
+	Not  *Clause    `json:"not,omitempty"`
+	Cond *Condition `json:"condition,omitempty"`
+	Tag  *Tag       `json:"tag,omitempty"`
+}
+
+// Condition matches an asset field against an operator/value pair.
+type Condition struct {
+	Field string `json:"field"`
+	Op    string `json:"op"`
+	Val   string `json:"val"`
+}
+
+// Tag matches a key/value tag on an asset.
+type Tag struct {
+	Key string `json:"key"`
+	Val string `json:"val"`
+}
+
+// ErrPerspectiveNotFound is returned when a Perspective doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrPerspectiveNotFound = errors.New("Perspective not found")
+
+// GetAllPerspectives gets all Perspectives.
+func (s *Client) GetAllPerspectives() ([]Perspective, error) {
+	return s.GetAllPerspectivesContext(context.Background())
+}
+
+// GetAllPerspectivesContext gets all Perspectives.
+func (s *Client) GetAllPerspectivesContext(ctx context.Context) ([]Perspective, error) {
+	var perspectives Perspectives
+	err := s.do(ctx, http.MethodGet, "perspective_schemas", nil, &perspectives, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return perspectives.Perspectives, nil
+}
+
+// GetPerspective gets the Perspective with the specified CloudHealth Perspective ID.
+func (s *Client) GetPerspective(id string) (*Perspective, error) {
+	return s.GetPerspectiveContext(context.Background(), id)
+}
+
+// GetPerspectiveContext gets the Perspective with the specified CloudHealth Perspective ID.
+func (s *Client) GetPerspectiveContext(ctx context.Context, id string) (*Perspective, error) {
+	var perspective Perspective
+	path := fmt.Sprintf("perspective_schemas/%s", id)
+	err := s.do(ctx, http.MethodGet, path, nil, &perspective, ErrPerspectiveNotFound, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	perspective.ID = id
+	return &perspective, nil
+}
+
+// CreatePerspective creates a new Perspective in CloudHealth.
+func (s *Client) CreatePerspective(perspective Perspective) (*Perspective, error) {
+	return s.CreatePerspectiveContext(context.Background(), perspective)
+}
+
+// CreatePerspectiveContext creates a new Perspective in CloudHealth.
+func (s *Client) CreatePerspectiveContext(ctx context.Context, perspective Perspective) (*Perspective, error) {
+	var created Perspective
+	err := s.do(ctx, http.MethodPost, "perspective_schemas", perspective, &created, nil, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdatePerspective updates an existing Perspective in CloudHealth. includeVersion adds
+// ?include_version=true to the request; Perspective has no version field, so a schema
+// version in the response is not returned to the caller.
+func (s *Client) UpdatePerspective(id string, perspective Perspective, includeVersion bool) (*Perspective, error) {
+	return s.UpdatePerspectiveContext(context.Background(), id, perspective, includeVersion)
+}
+
+// UpdatePerspectiveContext updates an existing Perspective in CloudHealth. includeVersion
+// adds ?include_version=true to the request; Perspective has no version field, so a
+// schema version in the response is not returned to the caller.
+func (s *Client) UpdatePerspectiveContext(ctx context.Context, id string, perspective Perspective, includeVersion bool) (*Perspective, error) {
+	path := fmt.Sprintf("perspective_schemas/%s", id)
+	if includeVersion {
+		q := url.Values{}
+		q.Set("include_version", "true")
+		path += "?" + q.Encode()
+	}
+
+	var updated Perspective
+	err := s.do(ctx, http.MethodPut, path, perspective, &updated, ErrPerspectiveNotFound, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = id
+	return &updated, nil
+}
+
+// DeletePerspective removes the Perspective with the specified CloudHealth ID. When hard
+// is true, CloudHealth permanently deletes the Perspective (?hard_delete=true) instead of
+// archiving it.
+func (s *Client) DeletePerspective(id string, hard bool) error {
+	return s.DeletePerspectiveContext(context.Background(), id, hard)
+}
+
+// DeletePerspectiveContext removes the Perspective with the specified CloudHealth ID. When
+// hard is true, CloudHealth permanently deletes the Perspective (?hard_delete=true) instead
+// of archiving it.
+func (s *Client) DeletePerspectiveContext(ctx context.Context, id string, hard bool) error {
+	path := fmt.Sprintf("perspective_schemas/%s", id)
+	if hard {
+		q := url.Values{}
+		q.Set("hard_delete", "true")
+		path += "?" + q.Encode()
+	}
+	return s.do(ctx, http.MethodDelete, path, nil, nil, ErrPerspectiveNotFound, http.StatusOK, http.StatusNoContent)
+}
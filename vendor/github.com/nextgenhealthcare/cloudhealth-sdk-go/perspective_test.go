@@ -0,0 +1,124 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newPerspectiveTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	endpoint, err := url.Parse(baseURL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	return &Client{ApiKey: "test-key", EndpointURL: endpoint, Timeout: 5}
+}
+
+// TestPerspectiveSchemaMarshalPreservesOrder verifies Constants and Rules round-trip
+// through JSON in insertion order. CloudHealth treats both as ordered arrays (e.g.
+// rule evaluation order matters for categorize/filter rules), so a marshal that
+// silently reordered them would corrupt the Perspective on write.
+func TestPerspectiveSchemaMarshalPreservesOrder(t *testing.T) {
+	schema := PerspectiveSchema{
+		Name: "by-team",
+		Constants: []PerspectiveConstant{
+			{Type: ConstantKindStaticGroup, Name: "third"},
+			{Type: ConstantKindStaticGroup, Name: "first"},
+			{Type: ConstantKindStaticGroup, Name: "second"},
+		},
+		Rules: []PerspectiveRule{
+			{Type: "filter", CategoryRefID: "r3"},
+			{Type: "filter", CategoryRefID: "r1"},
+			{Type: "filter", CategoryRefID: "r2"},
+		},
+	}
+
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var decoded PerspectiveSchema
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	gotConstants := []string{decoded.Constants[0].Name, decoded.Constants[1].Name, decoded.Constants[2].Name}
+	wantConstants := []string{"third", "first", "second"}
+	for i := range wantConstants {
+		if gotConstants[i] != wantConstants[i] {
+			t.Fatalf("constants order not preserved: got %v, want %v", gotConstants, wantConstants)
+		}
+	}
+
+	gotRules := []string{decoded.Rules[0].CategoryRefID, decoded.Rules[1].CategoryRefID, decoded.Rules[2].CategoryRefID}
+	wantRules := []string{"r3", "r1", "r2"}
+	for i := range wantRules {
+		if gotRules[i] != wantRules[i] {
+			t.Fatalf("rules order not preserved: got %v, want %v", gotRules, wantRules)
+		}
+	}
+}
+
+// TestPerspectiveCRUDRoundTrip exercises Create/Get/Update/Delete against an
+// httptest.Server, and confirms Get and Update both stamp the path ID onto the
+// returned Perspective since CloudHealth's perspective_schemas responses don't
+// carry a usable top-level id.
+func TestPerspectiveCRUDRoundTrip(t *testing.T) {
+	const id = "abc123"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/perspective_schemas":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Perspective{Name: "by-team"})
+		case r.Method == http.MethodGet && r.URL.Path == "/perspective_schemas/"+id:
+			json.NewEncoder(w).Encode(Perspective{Name: "by-team"})
+		case r.Method == http.MethodPut && r.URL.Path == "/perspective_schemas/"+id:
+			json.NewEncoder(w).Encode(Perspective{Name: "by-team-renamed"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/perspective_schemas/"+id:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newPerspectiveTestClient(t, server.URL)
+
+	created, err := client.CreatePerspectiveContext(context.Background(), Perspective{Name: "by-team"})
+	if err != nil {
+		t.Fatalf("CreatePerspectiveContext: %v", err)
+	}
+	if created.Name != "by-team" {
+		t.Fatalf("expected created name 'by-team', got %q", created.Name)
+	}
+
+	got, err := client.GetPerspectiveContext(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetPerspectiveContext: %v", err)
+	}
+	if got.ID != id {
+		t.Fatalf("expected Get to stamp ID %q, got %q", id, got.ID)
+	}
+
+	updated, err := client.UpdatePerspectiveContext(context.Background(), id, Perspective{Name: "by-team-renamed"}, false)
+	if err != nil {
+		t.Fatalf("UpdatePerspectiveContext: %v", err)
+	}
+	if updated.ID != id {
+		t.Fatalf("expected Update to stamp ID %q, got %q", id, updated.ID)
+	}
+	if updated.Name != "by-team-renamed" {
+		t.Fatalf("expected updated name 'by-team-renamed', got %q", updated.Name)
+	}
+
+	if err := client.DeletePerspectiveContext(context.Background(), id, false); err != nil {
+		t.Fatalf("DeletePerspectiveContext: %v", err)
+	}
+}